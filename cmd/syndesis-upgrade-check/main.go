@@ -0,0 +1,112 @@
+// Command syndesis-upgrade-check reports, for every Syndesis resource in the
+// cluster, the version currently installed, the version this operator would
+// upgrade it to, and the multi-step path between them - without mutating
+// InstallationStatus. Inspired by constellation's "upgrade check" UX.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/sirupsen/logrus"
+	"github.com/syndesisio/syndesis-operator/pkg/apis/syndesis/v1alpha1"
+	"github.com/syndesisio/syndesis-operator/pkg/syndesis/upgradecheck"
+	"github.com/syndesisio/syndesis-operator/pkg/syndesis/version"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+var syndesesResource = v1alpha1.SchemeGroupVersion.WithResource("syndeses")
+
+func main() {
+	namespace := flag.String("namespace", "", "only check Syndesis resources in this namespace (default: all namespaces)")
+	kubeconfig := flag.String("kubeconfig", "", "path to a kubeconfig file (default: in-cluster config)")
+	flag.Parse()
+
+	if err := run(*namespace, *kubeconfig); err != nil {
+		logrus.Fatal(err)
+	}
+}
+
+func run(namespace, kubeconfigPath string) error {
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	if err != nil {
+		return fmt.Errorf("building kube config: %v", err)
+	}
+
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("building dynamic client: %v", err)
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("building kubernetes client: %v", err)
+	}
+	version.SetNamespaceVersionGetter(namespaceVersionGetter(kubeClient))
+
+	operatorVersion, err := version.GetSyndesisVersionFromOperatorTemplate()
+	if err != nil {
+		return fmt.Errorf("resolving operator version: %v", err)
+	}
+
+	list, err := client.Resource(syndesesResource).Namespace(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("listing syndeses: %v", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tCURRENT\tTARGET\tPATH")
+
+	for _, item := range list.Items {
+		syndesis, err := toSyndesis(item)
+		if err != nil {
+			logrus.Warn("skipping ", item.GetNamespace(), "/", item.GetName(), ": ", err)
+			continue
+		}
+
+		upgrade, err := upgradecheck.Compute(syndesis, operatorVersion)
+		if err != nil {
+			logrus.Warn("computing upgrade for ", syndesis.Namespace, "/", syndesis.Name, ": ", err)
+			continue
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%v\n", syndesis.Namespace, syndesis.Name, upgrade.CurrentVersion, upgrade.TargetVersion, upgrade.Path)
+	}
+
+	return w.Flush()
+}
+
+// namespaceVersionGetter resolves installed Syndesis versions through
+// kubeClient, built from this command's own --kubeconfig flag, instead of
+// letting version.GetSyndesisVersionFromNamespace fall back to the
+// operator-sdk's package-global client, which this standalone binary never
+// bootstraps.
+func namespaceVersionGetter(kubeClient kubernetes.Interface) version.NamespaceVersionGetter {
+	return func(namespace string) (string, error) {
+		cm, err := kubeClient.CoreV1().ConfigMaps(namespace).Get(version.SyndesisVersionConfigMapName, metav1.GetOptions{})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				return "", nil
+			}
+			return "", err
+		}
+
+		return cm.Data[version.SyndesisVersionConfigMapKey], nil
+	}
+}
+
+func toSyndesis(u unstructured.Unstructured) (*v1alpha1.Syndesis, error) {
+	syndesis := &v1alpha1.Syndesis{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, syndesis); err != nil {
+		return nil, err
+	}
+	return syndesis, nil
+}
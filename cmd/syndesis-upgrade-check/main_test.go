@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestToSyndesis(t *testing.T) {
+	u := unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "syndesis.io/v1alpha1",
+			"kind":       "Syndesis",
+			"metadata": map[string]interface{}{
+				"name":      "app",
+				"namespace": "my-namespace",
+			},
+		},
+	}
+
+	syndesis, err := toSyndesis(u)
+	if err != nil {
+		t.Fatalf("toSyndesis() returned error: %v", err)
+	}
+
+	if syndesis.Name != "app" || syndesis.Namespace != "my-namespace" {
+		t.Errorf("got name=%q namespace=%q, want name=app namespace=my-namespace", syndesis.Name, syndesis.Namespace)
+	}
+}
+
+func TestToSyndesisInvalidObject(t *testing.T) {
+	u := unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				// name must be a string; this forces the unstructured conversion to fail.
+				"name": 42,
+			},
+		},
+	}
+
+	if _, err := toSyndesis(u); err == nil {
+		t.Error("toSyndesis() = nil error, want an error for a malformed object")
+	}
+}
@@ -0,0 +1,64 @@
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SyndesisConditionType is the type of a SyndesisCondition.
+type SyndesisConditionType string
+
+const (
+	// SyndesisConditionUpgrading is true while an upgrade pod/job is in flight.
+	SyndesisConditionUpgrading SyndesisConditionType = "Upgrading"
+	// SyndesisConditionUpgradePodReady is true once the current hop's upgrade pod has been created.
+	SyndesisConditionUpgradePodReady SyndesisConditionType = "UpgradePodReady"
+	// SyndesisConditionUpgradeSucceeded is true once the whole upgrade chain has completed.
+	SyndesisConditionUpgradeSucceeded SyndesisConditionType = "UpgradeSucceeded"
+	// SyndesisConditionUpgradeFailed is true when the current hop's upgrade pod failed.
+	SyndesisConditionUpgradeFailed SyndesisConditionType = "UpgradeFailed"
+)
+
+// SyndesisCondition describes the state of one aspect of a Syndesis installation
+// at a point in time.
+type SyndesisCondition struct {
+	Type               SyndesisConditionType `json:"type"`
+	Status             v1.ConditionStatus    `json:"status"`
+	Reason             string                `json:"reason,omitempty"`
+	Message            string                `json:"message,omitempty"`
+	LastTransitionTime metav1.Time           `json:"lastTransitionTime,omitempty"`
+}
+
+// GetCondition returns the condition of the given type, or nil if it isn't set.
+func (s *SyndesisStatus) GetCondition(t SyndesisConditionType) *SyndesisCondition {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == t {
+			return &s.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition sets or updates the condition of the given type, bumping
+// LastTransitionTime only when the status actually changes.
+func (s *SyndesisStatus) SetCondition(t SyndesisConditionType, status v1.ConditionStatus, reason, message string) {
+	now := metav1.Now()
+
+	if existing := s.GetCondition(t); existing != nil {
+		if existing.Status != status {
+			existing.LastTransitionTime = now
+		}
+		existing.Status = status
+		existing.Reason = reason
+		existing.Message = message
+		return
+	}
+
+	s.Conditions = append(s.Conditions, SyndesisCondition{
+		Type:               t,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: now,
+	})
+}
@@ -0,0 +1,75 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestGetConditionMissing(t *testing.T) {
+	status := &SyndesisStatus{}
+
+	if c := status.GetCondition(SyndesisConditionUpgrading); c != nil {
+		t.Errorf("GetCondition() = %v, want nil", c)
+	}
+}
+
+func TestSetConditionAddsNewCondition(t *testing.T) {
+	status := &SyndesisStatus{}
+
+	status.SetCondition(SyndesisConditionUpgrading, v1.ConditionTrue, "Started", "upgrading")
+
+	c := status.GetCondition(SyndesisConditionUpgrading)
+	if c == nil {
+		t.Fatal("GetCondition() = nil, want the condition just set")
+	}
+	if c.Status != v1.ConditionTrue || c.Reason != "Started" || c.Message != "upgrading" {
+		t.Errorf("got %+v, want Status=True Reason=Started Message=upgrading", c)
+	}
+	if c.LastTransitionTime.IsZero() {
+		t.Error("LastTransitionTime was not set on a new condition")
+	}
+}
+
+func TestSetConditionOnlyBumpsTransitionTimeOnStatusChange(t *testing.T) {
+	status := &SyndesisStatus{}
+	status.SetCondition(SyndesisConditionUpgrading, v1.ConditionTrue, "Started", "upgrading")
+	firstTransition := status.GetCondition(SyndesisConditionUpgrading).LastTransitionTime
+
+	// Same status, different reason/message: LastTransitionTime must not move.
+	status.SetCondition(SyndesisConditionUpgrading, v1.ConditionTrue, "StillGoing", "still upgrading")
+	c := status.GetCondition(SyndesisConditionUpgrading)
+	if c.Reason != "StillGoing" || c.Message != "still upgrading" {
+		t.Errorf("got %+v, want Reason=StillGoing Message=still upgrading", c)
+	}
+	if !c.LastTransitionTime.Equal(&firstTransition) {
+		t.Errorf("LastTransitionTime changed from %v to %v without a status change", firstTransition, c.LastTransitionTime)
+	}
+
+	// Status changes: LastTransitionTime must move.
+	status.SetCondition(SyndesisConditionUpgrading, v1.ConditionFalse, "Done", "")
+	c = status.GetCondition(SyndesisConditionUpgrading)
+	if c.Status != v1.ConditionFalse {
+		t.Errorf("Status = %v, want False", c.Status)
+	}
+	if c.LastTransitionTime.Equal(&firstTransition) {
+		t.Errorf("LastTransitionTime stayed at %v after a status change", firstTransition)
+	}
+}
+
+func TestSetConditionKeepsConditionsDistinctByType(t *testing.T) {
+	status := &SyndesisStatus{}
+
+	status.SetCondition(SyndesisConditionUpgrading, v1.ConditionTrue, "", "")
+	status.SetCondition(SyndesisConditionUpgradePodReady, v1.ConditionTrue, "", "")
+
+	if len(status.Conditions) != 2 {
+		t.Fatalf("got %d conditions, want 2", len(status.Conditions))
+	}
+	if status.GetCondition(SyndesisConditionUpgrading) == nil {
+		t.Error("Upgrading condition missing")
+	}
+	if status.GetCondition(SyndesisConditionUpgradePodReady) == nil {
+		t.Error("UpgradePodReady condition missing")
+	}
+}
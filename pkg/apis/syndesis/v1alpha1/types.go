@@ -0,0 +1,124 @@
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SyndesisInstallationStatus represents the high level phase of a Syndesis installation.
+type SyndesisInstallationStatus string
+
+const (
+	SyndesisInstallationStatusInstalling            SyndesisInstallationStatus = "Installing"
+	SyndesisInstallationStatusInstalled             SyndesisInstallationStatus = "Installed"
+	SyndesisInstallationStatusUpgrading             SyndesisInstallationStatus = "Upgrading"
+	SyndesisInstallationStatusUpgradeFailureBackoff SyndesisInstallationStatus = "UpgradeFailureBackoff"
+	// SyndesisInstallationStatusUpgradeAborted is reached once MaxUpgradeAttempts
+	// is exceeded. Leaving it requires human intervention: clearing
+	// Status.UpgradeAttempts or setting Status.ForceUpgrade, either of which
+	// Backoff picks up to move the installation back to
+	// SyndesisInstallationStatusUpgrading.
+	SyndesisInstallationStatusUpgradeAborted SyndesisInstallationStatus = "UpgradeAborted"
+	// SyndesisInstallationStatusPostUpgrading runs the post-upgrade migration Job
+	// once the final upgrade hop's pod has succeeded and the namespace version
+	// matches the operator version.
+	SyndesisInstallationStatusPostUpgrading SyndesisInstallationStatus = "PostUpgrading"
+)
+
+// SyndesisStatusReason gives the reason behind the current InstallationStatus.
+type SyndesisStatusReason string
+
+const (
+	SyndesisStatusReasonMissing              SyndesisStatusReason = ""
+	SyndesisStatusReasonUpgradePodFailed     SyndesisStatusReason = "UpgradePodFailed"
+	SyndesisStatusReasonPreconditionFailed   SyndesisStatusReason = "PreconditionFailed"
+	SyndesisStatusReasonPostUpgradeJobFailed SyndesisStatusReason = "PostUpgradeJobFailed"
+)
+
+// Syndesis is the Schema for the syndeses API.
+type Syndesis struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SyndesisSpec   `json:"spec,omitempty"`
+	Status SyndesisStatus `json:"status,omitempty"`
+}
+
+// SyndesisList contains a list of Syndesis.
+type SyndesisList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Syndesis `json:"items"`
+}
+
+// SyndesisSpec defines the desired state of Syndesis.
+type SyndesisSpec struct {
+	// UpgradeBackoffBase is the base duration used to compute the exponential
+	// backoff between upgrade attempts. Defaults to DefaultUpgradeBackoffBase.
+	UpgradeBackoffBase *metav1.Duration `json:"upgradeBackoffBase,omitempty"`
+	// UpgradeBackoffCap caps the computed backoff duration. Defaults to
+	// DefaultUpgradeBackoffCap.
+	UpgradeBackoffCap *metav1.Duration `json:"upgradeBackoffCap,omitempty"`
+	// MaxUpgradeAttempts is the number of failed upgrade attempts allowed before
+	// the installation is moved to SyndesisInstallationStatusUpgradeAborted.
+	// Defaults to DefaultMaxUpgradeAttempts.
+	MaxUpgradeAttempts int `json:"maxUpgradeAttempts,omitempty"`
+
+	// PostUpgrade overrides the Job run once the final upgrade hop's pod succeeds, to
+	// perform idempotent data migrations (schema fixups, connector
+	// re-registration, secret rotation, ...).
+	PostUpgrade PostUpgradeSpec `json:"postUpgrade,omitempty"`
+}
+
+// PostUpgradeSpec overrides the post-upgrade Job rendered from the operator's
+// post-upgrade template.
+type PostUpgradeSpec struct {
+	ServiceAccountName string      `json:"serviceAccountName,omitempty"`
+	Image              string      `json:"image,omitempty"`
+	Env                []v1.EnvVar `json:"env,omitempty"`
+}
+
+// SyndesisStatus defines the observed state of Syndesis.
+type SyndesisStatus struct {
+	InstallationStatus SyndesisInstallationStatus `json:"installationStatus,omitempty"`
+	Reason             SyndesisStatusReason       `json:"reason,omitempty"`
+	Version            string                     `json:"version,omitempty"`
+
+	ForceUpgrade       bool         `json:"forceUpgrade,omitempty"`
+	LastUpgradeFailure *metav1.Time `json:"lastUpgradeFailure,omitempty"`
+	UpgradeAttempts    int          `json:"upgradeAttempts,omitempty"`
+
+	// UpgradeTargets is the ordered chain of intermediate versions computed to get
+	// from the namespace's installed version to the operator's target version.
+	UpgradeTargets []string `json:"upgradeTargets,omitempty"`
+	// UpgradeStep is the index, within UpgradeTargets, of the hop currently being
+	// applied or last completed.
+	UpgradeStep int `json:"upgradeStep,omitempty"`
+
+	// Conditions is the set of detailed condition reports tracking the upgrade
+	// lifecycle, in addition to the coarse-grained InstallationStatus above.
+	Conditions []SyndesisCondition `json:"conditions,omitempty"`
+
+	// PostUpgradeJobStartedAt records when the post-upgrade Job was created, used
+	// to enforce its own completion timeout independently of UpgradeAttempts.
+	PostUpgradeJobStartedAt *metav1.Time `json:"postUpgradeJobStartedAt,omitempty"`
+
+	// AvailableUpgrade reports the upgrade this operator would perform, computed
+	// without mutating InstallationStatus. Lets cluster admins preview an
+	// upgrade before flipping InstallationStatus to Upgrading.
+	AvailableUpgrade *AvailableUpgrade `json:"availableUpgrade,omitempty"`
+}
+
+// AvailableUpgrade describes the upgrade the operator would perform for a
+// Syndesis resource, were it moved into SyndesisInstallationStatusUpgrading.
+type AvailableUpgrade struct {
+	CurrentVersion string `json:"currentVersion,omitempty"`
+	TargetVersion  string `json:"targetVersion,omitempty"`
+	// Path is the ordered chain of intermediate versions, as computed by
+	// version.ComputeUpgradePath.
+	Path []string `json:"path,omitempty"`
+	// CheckedAt is when this was last written; it is not refreshed while
+	// CurrentVersion and TargetVersion stay the same.
+	CheckedAt metav1.Time `json:"checkedAt,omitempty"`
+}
@@ -0,0 +1,167 @@
+// +build !ignore_autogenerated
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto copies the receiver into out.
+func (in *Syndesis) DeepCopyInto(out *Syndesis) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy creates a new Syndesis.
+func (in *Syndesis) DeepCopy() *Syndesis {
+	if in == nil {
+		return nil
+	}
+	out := new(Syndesis)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *Syndesis) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *SyndesisList) DeepCopyInto(out *SyndesisList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		items := make([]Syndesis, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&items[i])
+		}
+		out.Items = items
+	}
+}
+
+// DeepCopy creates a new SyndesisList.
+func (in *SyndesisList) DeepCopy() *SyndesisList {
+	if in == nil {
+		return nil
+	}
+	out := new(SyndesisList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *SyndesisList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *SyndesisSpec) DeepCopyInto(out *SyndesisSpec) {
+	*out = *in
+	if in.UpgradeBackoffBase != nil {
+		d := *in.UpgradeBackoffBase
+		out.UpgradeBackoffBase = &d
+	}
+	if in.UpgradeBackoffCap != nil {
+		d := *in.UpgradeBackoffCap
+		out.UpgradeBackoffCap = &d
+	}
+	in.PostUpgrade.DeepCopyInto(&out.PostUpgrade)
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *PostUpgradeSpec) DeepCopyInto(out *PostUpgradeSpec) {
+	*out = *in
+	if in.Env != nil {
+		env := make([]v1.EnvVar, len(in.Env))
+		copy(env, in.Env)
+		out.Env = env
+	}
+}
+
+// DeepCopy creates a new PostUpgradeSpec.
+func (in *PostUpgradeSpec) DeepCopy() *PostUpgradeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostUpgradeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopy creates a new SyndesisSpec.
+func (in *SyndesisSpec) DeepCopy() *SyndesisSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SyndesisSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *SyndesisStatus) DeepCopyInto(out *SyndesisStatus) {
+	*out = *in
+	if in.LastUpgradeFailure != nil {
+		out.LastUpgradeFailure = in.LastUpgradeFailure.DeepCopy()
+	}
+	if in.UpgradeTargets != nil {
+		targets := make([]string, len(in.UpgradeTargets))
+		copy(targets, in.UpgradeTargets)
+		out.UpgradeTargets = targets
+	}
+	if in.Conditions != nil {
+		conditions := make([]SyndesisCondition, len(in.Conditions))
+		copy(conditions, in.Conditions)
+		out.Conditions = conditions
+	}
+	if in.PostUpgradeJobStartedAt != nil {
+		out.PostUpgradeJobStartedAt = in.PostUpgradeJobStartedAt.DeepCopy()
+	}
+	if in.AvailableUpgrade != nil {
+		au := in.AvailableUpgrade.DeepCopy()
+		out.AvailableUpgrade = au
+	}
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *AvailableUpgrade) DeepCopyInto(out *AvailableUpgrade) {
+	*out = *in
+	if in.Path != nil {
+		path := make([]string, len(in.Path))
+		copy(path, in.Path)
+		out.Path = path
+	}
+	out.CheckedAt = in.CheckedAt
+}
+
+// DeepCopy creates a new AvailableUpgrade.
+func (in *AvailableUpgrade) DeepCopy() *AvailableUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(AvailableUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopy creates a new SyndesisStatus.
+func (in *SyndesisStatus) DeepCopy() *SyndesisStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SyndesisStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,138 @@
+package action
+
+import (
+	"time"
+
+	"github.com/operator-framework/operator-sdk/pkg/sdk"
+	"github.com/sirupsen/logrus"
+	"github.com/syndesisio/syndesis-operator/pkg/apis/syndesis/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	// DefaultUpgradeBackoffBase is the base duration used to compute the
+	// exponential backoff between upgrade attempts, when Spec.UpgradeBackoffBase
+	// isn't set.
+	DefaultUpgradeBackoffBase = 30 * time.Second
+	// DefaultUpgradeBackoffCap caps the computed backoff duration, when
+	// Spec.UpgradeBackoffCap isn't set.
+	DefaultUpgradeBackoffCap = time.Hour
+	// DefaultMaxUpgradeAttempts is the number of failed upgrade attempts allowed
+	// before the installation is aborted, when Spec.MaxUpgradeAttempts isn't set.
+	DefaultMaxUpgradeAttempts = 5
+)
+
+// Backoff enforces the wait between failed upgrade attempts, and aborts the
+// upgrade once MaxUpgradeAttempts is exceeded. It runs ahead of Upgrade in the
+// reconcile loop: while CanExecute is true, Upgrade must not run.
+type Backoff struct {
+	recorder record.EventRecorder
+}
+
+// NewBackoff creates a Backoff action that reports the abort transition through
+// recorder.
+func NewBackoff(recorder record.EventRecorder) *Backoff {
+	return &Backoff{recorder: recorder}
+}
+
+func (a *Backoff) CanExecute(syndesis *v1alpha1.Syndesis) bool {
+	switch syndesis.Status.InstallationStatus {
+	case v1alpha1.SyndesisInstallationStatusUpgradeFailureBackoff, v1alpha1.SyndesisInstallationStatusUpgradeAborted:
+		return true
+	default:
+		return false
+	}
+}
+
+func (a *Backoff) Execute(syndesis *v1alpha1.Syndesis) error {
+	if syndesis.Status.InstallationStatus == v1alpha1.SyndesisInstallationStatusUpgradeAborted {
+		return a.recoverFromAborted(syndesis)
+	}
+
+	maxAttempts := syndesis.Spec.MaxUpgradeAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxUpgradeAttempts
+	}
+
+	if syndesis.Status.UpgradeAttempts >= maxAttempts && !syndesis.Status.ForceUpgrade {
+		logrus.Warn("Syndesis resource ", syndesis.Name, " exceeded ", maxAttempts,
+			" upgrade attempts, aborting. Clear status.upgradeAttempts or set status.forceUpgrade to retry.")
+
+		target := syndesis.DeepCopy()
+		target.Status.InstallationStatus = v1alpha1.SyndesisInstallationStatusUpgradeAborted
+		target.Status.SetCondition(v1alpha1.SyndesisConditionUpgrading, v1.ConditionFalse, "MaxUpgradeAttemptsExceeded", "")
+
+		if a.recorder != nil {
+			a.recorder.Event(syndesis, v1.EventTypeWarning, "UpgradeAborted", "exceeded max upgrade attempts")
+		}
+
+		return sdk.Update(target)
+	}
+
+	nextAttemptAt := a.nextAttemptAt(syndesis)
+	if time.Now().Before(nextAttemptAt) {
+		// Still waiting out the backoff window.
+		return nil
+	}
+
+	logrus.Info("Syndesis resource ", syndesis.Name, " backoff window elapsed, retrying upgrade")
+
+	target := syndesis.DeepCopy()
+	target.Status.InstallationStatus = v1alpha1.SyndesisInstallationStatusUpgrading
+	if syndesis.Status.Reason == v1alpha1.SyndesisStatusReasonUpgradePodFailed {
+		// Recreate the failed upgrade pod instead of observing its failure again.
+		target.Status.ForceUpgrade = true
+	}
+
+	return sdk.Update(target)
+}
+
+// recoverFromAborted moves syndesis back to Upgrading once a human has acted
+// on a SyndesisInstallationStatusUpgradeAborted installation, either by
+// clearing Status.UpgradeAttempts or by setting Status.ForceUpgrade. It also
+// re-arms ForceUpgrade so Upgrade.Execute recreates the still-failed upgrade
+// pod instead of immediately observing the same failure again.
+func (a *Backoff) recoverFromAborted(syndesis *v1alpha1.Syndesis) error {
+	if !syndesis.Status.ForceUpgrade && syndesis.Status.UpgradeAttempts != 0 {
+		// Still waiting on human intervention.
+		return nil
+	}
+
+	logrus.Info("Syndesis resource ", syndesis.Name, " recovering from UpgradeAborted, retrying upgrade")
+
+	target := syndesis.DeepCopy()
+	target.Status.InstallationStatus = v1alpha1.SyndesisInstallationStatusUpgrading
+	target.Status.UpgradeAttempts = 0
+	target.Status.ForceUpgrade = true
+	target.Status.SetCondition(v1alpha1.SyndesisConditionUpgrading, v1.ConditionTrue, "RecoveredFromAbort", "")
+
+	if a.recorder != nil {
+		a.recorder.Event(syndesis, v1.EventTypeNormal, "UpgradeResumed", "recovered from UpgradeAborted")
+	}
+
+	return sdk.Update(target)
+}
+
+// nextAttemptAt computes LastUpgradeFailure + base * 2^UpgradeAttempts, capped.
+func (a *Backoff) nextAttemptAt(syndesis *v1alpha1.Syndesis) time.Time {
+	if syndesis.Status.LastUpgradeFailure == nil {
+		return time.Time{}
+	}
+
+	base := DefaultUpgradeBackoffBase
+	if syndesis.Spec.UpgradeBackoffBase != nil {
+		base = syndesis.Spec.UpgradeBackoffBase.Duration
+	}
+	backoffCap := DefaultUpgradeBackoffCap
+	if syndesis.Spec.UpgradeBackoffCap != nil {
+		backoffCap = syndesis.Spec.UpgradeBackoffCap.Duration
+	}
+
+	backoff := base << uint(syndesis.Status.UpgradeAttempts)
+	if backoff <= 0 || backoff > backoffCap {
+		backoff = backoffCap
+	}
+
+	return syndesis.Status.LastUpgradeFailure.Add(backoff)
+}
@@ -0,0 +1,112 @@
+package action
+
+import (
+	"testing"
+	"time"
+
+	"github.com/syndesisio/syndesis-operator/pkg/apis/syndesis/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBackoffCanExecute(t *testing.T) {
+	tests := []struct {
+		status v1alpha1.SyndesisInstallationStatus
+		want   bool
+	}{
+		{v1alpha1.SyndesisInstallationStatusUpgradeFailureBackoff, true},
+		{v1alpha1.SyndesisInstallationStatusUpgradeAborted, true},
+		{v1alpha1.SyndesisInstallationStatusUpgrading, false},
+		{v1alpha1.SyndesisInstallationStatusInstalled, false},
+	}
+
+	a := NewBackoff(nil)
+	for _, tt := range tests {
+		syndesis := &v1alpha1.Syndesis{Status: v1alpha1.SyndesisStatus{InstallationStatus: tt.status}}
+		if got := a.CanExecute(syndesis); got != tt.want {
+			t.Errorf("CanExecute() with status %q = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestNextAttemptAtNoFailureYet(t *testing.T) {
+	a := NewBackoff(nil)
+	syndesis := &v1alpha1.Syndesis{}
+
+	if got := a.nextAttemptAt(syndesis); !got.IsZero() {
+		t.Errorf("nextAttemptAt() = %v, want the zero time when LastUpgradeFailure is unset", got)
+	}
+}
+
+func TestNextAttemptAtDoublesPerAttempt(t *testing.T) {
+	failedAt := metav1.NewTime(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	a := NewBackoff(nil)
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, 30 * time.Second},
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{3, 4 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		syndesis := &v1alpha1.Syndesis{
+			Status: v1alpha1.SyndesisStatus{
+				LastUpgradeFailure: &failedAt,
+				UpgradeAttempts:    tt.attempts,
+			},
+		}
+
+		got := a.nextAttemptAt(syndesis)
+		want := failedAt.Add(tt.want)
+		if !got.Equal(want) {
+			t.Errorf("nextAttemptAt() with %d attempts = %v, want %v", tt.attempts, got, want)
+		}
+	}
+}
+
+func TestNextAttemptAtRespectsCap(t *testing.T) {
+	failedAt := metav1.NewTime(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	backoffCap := metav1.Duration{Duration: 5 * time.Minute}
+	a := NewBackoff(nil)
+
+	syndesis := &v1alpha1.Syndesis{
+		Spec: v1alpha1.SyndesisSpec{
+			UpgradeBackoffCap: &backoffCap,
+		},
+		Status: v1alpha1.SyndesisStatus{
+			LastUpgradeFailure: &failedAt,
+			UpgradeAttempts:    10, // would overflow/exceed the cap uncapped
+		},
+	}
+
+	got := a.nextAttemptAt(syndesis)
+	want := failedAt.Add(backoffCap.Duration)
+	if !got.Equal(want) {
+		t.Errorf("nextAttemptAt() = %v, want %v (capped)", got, want)
+	}
+}
+
+func TestNextAttemptAtCustomBase(t *testing.T) {
+	failedAt := metav1.NewTime(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	base := metav1.Duration{Duration: 10 * time.Second}
+	a := NewBackoff(nil)
+
+	syndesis := &v1alpha1.Syndesis{
+		Spec: v1alpha1.SyndesisSpec{
+			UpgradeBackoffBase: &base,
+		},
+		Status: v1alpha1.SyndesisStatus{
+			LastUpgradeFailure: &failedAt,
+			UpgradeAttempts:    2,
+		},
+	}
+
+	got := a.nextAttemptAt(syndesis)
+	want := failedAt.Add(40 * time.Second)
+	if !got.Equal(want) {
+		t.Errorf("nextAttemptAt() = %v, want %v", got, want)
+	}
+}
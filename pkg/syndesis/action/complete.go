@@ -0,0 +1,33 @@
+package action
+
+import (
+	"github.com/operator-framework/operator-sdk/pkg/sdk"
+	"github.com/syndesisio/syndesis-operator/pkg/apis/syndesis/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// completeInstallation marks the Syndesis resource as fully installed at
+// newVersion, clearing every piece of upgrade bookkeeping. Called by
+// PostUpgrade once the migration Job succeeds.
+func completeInstallation(recorder record.EventRecorder, syndesis *v1alpha1.Syndesis, newVersion string) error {
+	target := syndesis.DeepCopy()
+	target.Status.InstallationStatus = v1alpha1.SyndesisInstallationStatusInstalled
+	target.Status.Reason = v1alpha1.SyndesisStatusReasonMissing
+	target.Status.Version = newVersion
+	target.Status.LastUpgradeFailure = nil
+	target.Status.UpgradeAttempts = 0
+	target.Status.ForceUpgrade = false
+	target.Status.UpgradeTargets = nil
+	target.Status.UpgradeStep = 0
+	target.Status.PostUpgradeJobStartedAt = nil
+	target.Status.SetCondition(v1alpha1.SyndesisConditionUpgrading, v1.ConditionFalse, "UpgradeSucceeded", "")
+	target.Status.SetCondition(v1alpha1.SyndesisConditionUpgradeSucceeded, v1.ConditionTrue, "UpgradeSucceeded", "upgraded to version "+newVersion)
+	target.Status.SetCondition(v1alpha1.SyndesisConditionUpgradeFailed, v1.ConditionFalse, "UpgradeSucceeded", "")
+
+	if recorder != nil {
+		recorder.Event(syndesis, v1.EventTypeNormal, "UpgradeSucceeded", "upgraded to version "+newVersion)
+	}
+
+	return sdk.Update(target)
+}
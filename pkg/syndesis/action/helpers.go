@@ -0,0 +1,49 @@
+package action
+
+import (
+	"github.com/operator-framework/operator-sdk/pkg/sdk"
+	"github.com/syndesisio/syndesis-operator/pkg/apis/syndesis/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// syndesisInstallationStatusIs reports whether the given Syndesis resource is
+// currently in the given installation status.
+func syndesisInstallationStatusIs(syndesis *v1alpha1.Syndesis, status v1alpha1.SyndesisInstallationStatus) bool {
+	return syndesis.Status.InstallationStatus == status
+}
+
+// setNamespaceAndOwnerReference stamps a rendered resource with the Syndesis
+// resource's namespace and sets it as the resource's owner, so it gets garbage
+// collected together with the Syndesis resource.
+func setNamespaceAndOwnerReference(res runtime.Object, syndesis *v1alpha1.Syndesis) {
+	accessor, ok := res.(metav1.Object)
+	if !ok {
+		return
+	}
+
+	accessor.SetNamespace(syndesis.Namespace)
+	accessor.SetOwnerReferences([]metav1.OwnerReference{
+		*metav1.NewControllerRef(syndesis, v1alpha1.SchemeGroupVersion.WithKind("Syndesis")),
+	})
+}
+
+// createOrReplaceForce creates the given resource. When creation fails and
+// force is true, the resource is deleted and created again; when force is
+// false, the creation error is returned and nothing is replaced.
+func createOrReplaceForce(res runtime.Object, force bool) error {
+	err := sdk.Create(res)
+	if err == nil {
+		return nil
+	}
+
+	if !force {
+		return err
+	}
+
+	if delErr := sdk.Delete(res); delErr != nil {
+		return delErr
+	}
+
+	return sdk.Create(res)
+}
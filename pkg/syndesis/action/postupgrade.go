@@ -0,0 +1,186 @@
+package action
+
+import (
+	"errors"
+	"time"
+
+	"github.com/operator-framework/operator-sdk/pkg/sdk"
+	"github.com/sirupsen/logrus"
+	"github.com/syndesisio/syndesis-operator/pkg/apis/syndesis/v1alpha1"
+	syndesistemplate "github.com/syndesisio/syndesis-operator/pkg/syndesis/template"
+	"github.com/syndesisio/syndesis-operator/pkg/util"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	// postUpgradeJobPollInterval is the reconcile interval this action assumes
+	// when computing its completion timeout.
+	postUpgradeJobPollInterval = 5 * time.Second
+	// postUpgradeJobMaxPolls caps how long the post-upgrade Job is given to
+	// complete before it's treated as failed, modeled after longhorn-manager's
+	// post_upgrade command.
+	postUpgradeJobMaxPolls = 360
+)
+
+// PostUpgrade runs the post-upgrade migration Job once the final upgrade hop's
+// pod has succeeded, letting downstream users ship idempotent migration steps (schema
+// fixups, connector re-registration, secret rotation) that must run exactly
+// once per successful version bump.
+type PostUpgrade struct {
+	recorder record.EventRecorder
+}
+
+// NewPostUpgrade creates a PostUpgrade action that reports phase changes
+// through recorder.
+func NewPostUpgrade(recorder record.EventRecorder) *PostUpgrade {
+	return &PostUpgrade{recorder: recorder}
+}
+
+func (a *PostUpgrade) CanExecute(syndesis *v1alpha1.Syndesis) bool {
+	return syndesisInstallationStatusIs(syndesis, v1alpha1.SyndesisInstallationStatusPostUpgrading)
+}
+
+func (a *PostUpgrade) Execute(syndesis *v1alpha1.Syndesis) error {
+	if len(syndesis.Status.UpgradeTargets) == 0 {
+		return errors.New("no upgrade targets recorded, cannot determine the version being post-upgraded")
+	}
+	targetVersion := syndesis.Status.UpgradeTargets[len(syndesis.Status.UpgradeTargets)-1]
+
+	resources, err := a.getPostUpgradeResources(syndesis, targetVersion)
+	if err != nil {
+		return err
+	}
+
+	templateJob, err := a.findPostUpgradeJob(resources)
+	if err != nil {
+		return err
+	}
+	a.applyOverrides(templateJob, syndesis.Spec.PostUpgrade)
+
+	job := batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: syndesis.Namespace,
+			Name:      templateJob.Name,
+		},
+	}
+	err = sdk.Get(&job)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	if k8serrors.IsNotFound(err) {
+		logrus.Info("Creating post-upgrade job for syndesis resource ", syndesis.Name, " version ", targetVersion)
+
+		for _, res := range resources {
+			setNamespaceAndOwnerReference(res, syndesis)
+
+			if err := createOrReplaceForce(res, true); err != nil {
+				return err
+			}
+		}
+
+		target := syndesis.DeepCopy()
+		now := metav1.Now()
+		target.Status.PostUpgradeJobStartedAt = &now
+
+		if a.recorder != nil {
+			a.recorder.Event(syndesis, v1.EventTypeNormal, "PostUpgradeJobCreated", "created post-upgrade job for version "+targetVersion)
+		}
+
+		return sdk.Update(target)
+	}
+
+	if job.Status.Succeeded > 0 {
+		logrus.Info("Post-upgrade job succeeded for syndesis resource ", syndesis.Name, " version ", targetVersion)
+		return completeInstallation(a.recorder, syndesis, targetVersion)
+	}
+
+	if job.Status.Failed > 0 || a.timedOut(syndesis) {
+		logrus.Warn("Post-upgrade job failed or timed out for syndesis resource ", syndesis.Name, " version ", targetVersion)
+
+		// Delete the stale Job so the next time this action re-enters
+		// PostUpgrading (once Backoff moves the installation back to
+		// Upgrading) it finds no Job and actually retries the migration,
+		// instead of immediately observing the same failure again.
+		if delErr := sdk.Delete(&job); delErr != nil && !k8serrors.IsNotFound(delErr) {
+			return delErr
+		}
+
+		target := syndesis.DeepCopy()
+		target.Status.InstallationStatus = v1alpha1.SyndesisInstallationStatusUpgradeFailureBackoff
+		target.Status.Reason = v1alpha1.SyndesisStatusReasonPostUpgradeJobFailed
+		target.Status.LastUpgradeFailure = &metav1.Time{Time: time.Now()}
+		target.Status.UpgradeAttempts = target.Status.UpgradeAttempts + 1
+
+		if a.recorder != nil {
+			a.recorder.Event(syndesis, v1.EventTypeWarning, "PostUpgradeJobFailed", "post-upgrade job failed for version "+targetVersion)
+		}
+
+		return sdk.Update(target)
+	}
+
+	// Still running.
+	return nil
+}
+
+func (a *PostUpgrade) timedOut(syndesis *v1alpha1.Syndesis) bool {
+	if syndesis.Status.PostUpgradeJobStartedAt == nil {
+		return false
+	}
+
+	deadline := syndesis.Status.PostUpgradeJobStartedAt.Add(postUpgradeJobPollInterval * postUpgradeJobMaxPolls)
+	return time.Now().After(deadline)
+}
+
+// applyOverrides layers Spec.PostUpgrade onto the rendered Job template.
+func (a *PostUpgrade) applyOverrides(job *batchv1.Job, overrides v1alpha1.PostUpgradeSpec) {
+	if overrides.ServiceAccountName != "" {
+		job.Spec.Template.Spec.ServiceAccountName = overrides.ServiceAccountName
+	}
+
+	for i := range job.Spec.Template.Spec.Containers {
+		if overrides.Image != "" {
+			job.Spec.Template.Spec.Containers[i].Image = overrides.Image
+		}
+		if len(overrides.Env) > 0 {
+			job.Spec.Template.Spec.Containers[i].Env = append(job.Spec.Template.Spec.Containers[i].Env, overrides.Env...)
+		}
+	}
+}
+
+func (a *PostUpgrade) getPostUpgradeResources(syndesis *v1alpha1.Syndesis, targetVersion string) ([]runtime.Object, error) {
+	rawResources, err := syndesistemplate.GetPostUpgradeResources(syndesis, syndesistemplate.PostUpgradeParams{
+		InstallParams: syndesistemplate.InstallParams{
+			OAuthClientSecret: "-",
+		},
+		SyndesisVersion: targetVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]runtime.Object, 0, len(rawResources))
+	for _, obj := range rawResources {
+		res, err := util.LoadKubernetesResource(obj.Raw)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, res)
+	}
+
+	return resources, nil
+}
+
+func (a *PostUpgrade) findPostUpgradeJob(resources []runtime.Object) (*batchv1.Job, error) {
+	for _, res := range resources {
+		if job, ok := res.(*batchv1.Job); ok {
+			return job, nil
+		}
+	}
+	return nil, errors.New("post-upgrade job not found")
+}
@@ -0,0 +1,74 @@
+package action
+
+import (
+	"testing"
+	"time"
+
+	"github.com/syndesisio/syndesis-operator/pkg/apis/syndesis/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPostUpgradeTimedOutNotStarted(t *testing.T) {
+	a := NewPostUpgrade(nil)
+	syndesis := &v1alpha1.Syndesis{}
+
+	if a.timedOut(syndesis) {
+		t.Error("timedOut() = true, want false when PostUpgradeJobStartedAt is unset")
+	}
+}
+
+func TestPostUpgradeTimedOutBeforeDeadline(t *testing.T) {
+	a := NewPostUpgrade(nil)
+	startedAt := metav1.NewTime(time.Now().Add(-1 * time.Minute))
+	syndesis := &v1alpha1.Syndesis{
+		Status: v1alpha1.SyndesisStatus{
+			PostUpgradeJobStartedAt: &startedAt,
+		},
+	}
+
+	if a.timedOut(syndesis) {
+		t.Error("timedOut() = true, want false well before the deadline")
+	}
+}
+
+func TestPostUpgradeTimedOutAfterDeadline(t *testing.T) {
+	a := NewPostUpgrade(nil)
+	deadline := postUpgradeJobPollInterval * postUpgradeJobMaxPolls
+	startedAt := metav1.NewTime(time.Now().Add(-deadline - time.Minute))
+	syndesis := &v1alpha1.Syndesis{
+		Status: v1alpha1.SyndesisStatus{
+			PostUpgradeJobStartedAt: &startedAt,
+		},
+	}
+
+	if !a.timedOut(syndesis) {
+		t.Error("timedOut() = false, want true once the poll budget is exhausted")
+	}
+}
+
+func TestApplyOverrides(t *testing.T) {
+	job := &batchv1.Job{
+		Spec: batchv1.JobSpec{
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{Name: "migrate", Image: "original-image:1"}},
+				},
+			},
+		},
+	}
+	a := NewPostUpgrade(nil)
+
+	a.applyOverrides(job, v1alpha1.PostUpgradeSpec{
+		ServiceAccountName: "custom-sa",
+		Image:              "custom-image:latest",
+	})
+
+	if job.Spec.Template.Spec.ServiceAccountName != "custom-sa" {
+		t.Errorf("ServiceAccountName = %q, want custom-sa", job.Spec.Template.Spec.ServiceAccountName)
+	}
+	if job.Spec.Template.Spec.Containers[0].Image != "custom-image:latest" {
+		t.Errorf("Containers[0].Image = %q, want custom-image:latest", job.Spec.Template.Spec.Containers[0].Image)
+	}
+}
@@ -1,10 +1,12 @@
 package action
 
 import (
+	"context"
 	"errors"
 	"github.com/operator-framework/operator-sdk/pkg/sdk"
 	"github.com/sirupsen/logrus"
 	"github.com/syndesisio/syndesis-operator/pkg/apis/syndesis/v1alpha1"
+	"github.com/syndesisio/syndesis-operator/pkg/syndesis/precondition"
 	syndesistemplate "github.com/syndesisio/syndesis-operator/pkg/syndesis/template"
 	"github.com/syndesisio/syndesis-operator/pkg/syndesis/version"
 	"github.com/syndesisio/syndesis-operator/pkg/util"
@@ -12,6 +14,7 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"time"
 )
 
@@ -21,10 +24,22 @@ const (
 
 // Upgrades Syndesis to the version supported by this operator using the upgrade template.
 type Upgrade struct {
-	operatorVersion	string
+	operatorVersion string
+	recorder        record.EventRecorder
+}
+
+// NewUpgrade creates an Upgrade action that reports phase changes through recorder,
+// visible via "kubectl describe syndesis".
+func NewUpgrade(recorder record.EventRecorder) *Upgrade {
+	return &Upgrade{recorder: recorder}
 }
 
 func (a *Upgrade) CanExecute(syndesis *v1alpha1.Syndesis) bool {
+	// Preconditions are checked from Execute instead, gated on actually being
+	// about to create an upgrade pod. Running them here too would flip
+	// CanExecute to false the moment a precondition transiently fails while an
+	// upgrade pod is already in flight, stalling Execute from ever polling that
+	// pod's outcome again.
 	return syndesisInstallationStatusIs(syndesis, v1alpha1.SyndesisInstallationStatusUpgrading)
 }
 
@@ -41,9 +56,26 @@ func (a *Upgrade) Execute(syndesis *v1alpha1.Syndesis) error {
 	if err != nil {
 		return err
 	}
-	targetVersion := a.operatorVersion
 
-	resources, err := a.getUpgradeResources(syndesis)
+	upgradeTargets := syndesis.Status.UpgradeTargets
+	if len(upgradeTargets) == 0 {
+		upgradeTargets, err = version.ComputeUpgradePath(namespaceVersion, a.operatorVersion)
+		if err != nil {
+			return err
+		}
+
+		target := syndesis.DeepCopy()
+		target.Status.UpgradeTargets = upgradeTargets
+		target.Status.UpgradeStep = 0
+		if err := sdk.Update(target); err != nil {
+			return err
+		}
+		syndesis = target
+	}
+
+	targetVersion := upgradeTargets[syndesis.Status.UpgradeStep]
+
+	resources, err := a.getUpgradeResources(syndesis, targetVersion)
 	if err != nil {
 		return err
 	}
@@ -62,6 +94,17 @@ func (a *Upgrade) Execute(syndesis *v1alpha1.Syndesis) error {
 		// Upgrade pod not found or upgrade forced
 
 		if namespaceVersion != targetVersion {
+			if err := precondition.RunAll(context.TODO(), syndesis); err != nil {
+				logrus.Warn("Preconditions not met for upgrading syndesis resource ", syndesis.Name, " to version ", targetVersion, ": ", err)
+				a.recordEvent(syndesis, v1.EventTypeWarning, "PreconditionFailed", err.Error())
+
+				target := syndesis.DeepCopy()
+				target.Status.Reason = v1alpha1.SyndesisStatusReasonPreconditionFailed
+				target.Status.SetCondition(v1alpha1.SyndesisConditionUpgrading, v1.ConditionFalse, "PreconditionFailed", err.Error())
+
+				return sdk.Update(target)
+			}
+
 			logrus.Info("Upgrading syndesis resource ", syndesis.Name, " from version ", namespaceVersion, " to ", targetVersion)
 
 			// Set the correct service account for the upgrade pod
@@ -76,18 +119,28 @@ func (a *Upgrade) Execute(syndesis *v1alpha1.Syndesis) error {
 				}
 			}
 
-			if syndesis.Status.ForceUpgrade {
-				target := syndesis.DeepCopy()
-				target.Status.ForceUpgrade = false
+			a.recordEvent(syndesis, v1.EventTypeNormal, "UpgradePodCreated", "created upgrade pod for hop to version "+targetVersion)
 
-				return sdk.Update(target)
-			} else {
-				return nil
+			target := syndesis.DeepCopy()
+			// Preconditions just passed: clear a PreconditionFailed reason left
+			// over from an earlier reconcile, so it doesn't stick around through
+			// the rest of the upgrade.
+			if target.Status.Reason == v1alpha1.SyndesisStatusReasonPreconditionFailed {
+				target.Status.Reason = v1alpha1.SyndesisStatusReasonMissing
 			}
+			target.Status.SetCondition(v1alpha1.SyndesisConditionUpgrading, v1.ConditionTrue, "UpgradePodCreated", "upgrading to version "+targetVersion)
+			target.Status.SetCondition(v1alpha1.SyndesisConditionUpgradePodReady, v1.ConditionTrue, "UpgradePodCreated", "")
+
+			if target.Status.ForceUpgrade {
+				target.Status.ForceUpgrade = false
+				a.recordEvent(syndesis, v1.EventTypeNormal, "ForceUpgradeCleared", "force-upgrade toggle consumed")
+			}
+
+			return sdk.Update(target)
 		} else {
-			// No upgrade pod, no version change: upgraded
+			// No upgrade pod, no version change: this hop is upgraded
 			logrus.Info("Syndesis resource ", syndesis.Name, " already upgraded to version ", targetVersion)
-			return upgradeCompleted(syndesis, targetVersion)
+			return a.hopCompleted(syndesis, upgradeTargets, targetVersion)
 		}
 	} else {
 		// Upgrade pod present, checking the status
@@ -102,9 +155,11 @@ func (a *Upgrade) Execute(syndesis *v1alpha1.Syndesis) error {
 
 			if newNamespaceVersion == targetVersion {
 				logrus.Info("Syndesis resource ", syndesis.Name, " upgraded to version ", targetVersion)
-				return upgradeCompleted(syndesis, targetVersion)
+				return a.hopCompleted(syndesis, upgradeTargets, targetVersion)
 			} else {
 				logrus.Warn("Upgrade pod terminated successfully but Syndesis version (", newNamespaceVersion, ") does not reflect target version (", targetVersion, ") for resource ", syndesis.Name, ". Forcing upgrade.")
+				a.recordEvent(syndesis, v1.EventTypeWarning, "VersionMismatch", "upgrade pod succeeded but namespace version is still "+newNamespaceVersion+", forcing upgrade")
+
 				target := syndesis.DeepCopy()
 				target.Status.ForceUpgrade = true
 
@@ -113,6 +168,7 @@ func (a *Upgrade) Execute(syndesis *v1alpha1.Syndesis) error {
 		} else if upgradePod.Status.Phase == v1.PodFailed {
 			// Upgrade failed
 			logrus.Warn("Failure while upgrading Syndesis resource ", syndesis.Name, " to version ", targetVersion, ": upgrade pod failure")
+			a.recordEvent(syndesis, v1.EventTypeWarning, "UpgradePodFailed", "upgrade pod failed for hop to version "+targetVersion)
 
 			target := syndesis.DeepCopy()
 			target.Status.InstallationStatus = v1alpha1.SyndesisInstallationStatusUpgradeFailureBackoff
@@ -121,6 +177,7 @@ func (a *Upgrade) Execute(syndesis *v1alpha1.Syndesis) error {
 				Time: time.Now(),
 			}
 			target.Status.UpgradeAttempts = target.Status.UpgradeAttempts + 1
+			target.Status.SetCondition(v1alpha1.SyndesisConditionUpgradeFailed, v1.ConditionTrue, "UpgradePodFailed", "upgrade pod failed for hop to version "+targetVersion)
 
 			return sdk.Update(target)
 		} else {
@@ -133,24 +190,55 @@ func (a *Upgrade) Execute(syndesis *v1alpha1.Syndesis) error {
 
 }
 
-func upgradeCompleted(syndesis *v1alpha1.Syndesis, newVersion string) error {
+// hopCompleted advances the upgrade to the next intermediate version, or hands
+// off to PostUpgrade once hopVersion is the last entry of targets and it
+// matches the operator's own version.
+func (a *Upgrade) hopCompleted(syndesis *v1alpha1.Syndesis, targets []string, hopVersion string) error {
+	lastStep := len(targets) - 1
+
+	if syndesis.Status.UpgradeStep >= lastStep {
+		if hopVersion != a.operatorVersion {
+			return errors.New("final upgrade hop did not reach the operator version")
+		}
+		return a.enterPostUpgrade(syndesis, hopVersion)
+	}
+
+	target := syndesis.DeepCopy()
+	target.Status.UpgradeStep = syndesis.Status.UpgradeStep + 1
+	logrus.Info("Syndesis resource ", syndesis.Name, " completed upgrade hop to version ", hopVersion,
+		", moving to step ", target.Status.UpgradeStep, " of ", lastStep)
+	a.recordEvent(syndesis, v1.EventTypeNormal, "UpgradeHopCompleted", "completed upgrade hop to version "+hopVersion)
+
+	return sdk.Update(target)
+}
+
+// enterPostUpgrade hands off to the PostUpgrade action once the final upgrade
+// hop's pod has succeeded, instead of completing the installation directly.
+func (a *Upgrade) enterPostUpgrade(syndesis *v1alpha1.Syndesis, hopVersion string) error {
 	target := syndesis.DeepCopy()
-	target.Status.InstallationStatus = v1alpha1.SyndesisInstallationStatusInstalled
-	target.Status.Reason = v1alpha1.SyndesisStatusReasonMissing
-	target.Status.Version = newVersion
-	target.Status.LastUpgradeFailure = nil
-	target.Status.UpgradeAttempts = 0
-	target.Status.ForceUpgrade = false
+	target.Status.InstallationStatus = v1alpha1.SyndesisInstallationStatusPostUpgrading
+	target.Status.PostUpgradeJobStartedAt = nil
+
+	a.recordEvent(syndesis, v1.EventTypeNormal, "PostUpgradeStarted", "running post-upgrade migrations for version "+hopVersion)
 
 	return sdk.Update(target)
 }
 
-func (a *Upgrade) getUpgradeResources(syndesis *v1alpha1.Syndesis) ([]runtime.Object, error) {
+// recordEvent reports a phase change through the configured EventRecorder, if any.
+// The recorder is optional so Upgrade keeps working when constructed directly.
+func (a *Upgrade) recordEvent(syndesis *v1alpha1.Syndesis, eventType, reason, message string) {
+	if a.recorder == nil {
+		return
+	}
+	a.recorder.Event(syndesis, eventType, reason, message)
+}
+
+func (a *Upgrade) getUpgradeResources(syndesis *v1alpha1.Syndesis, targetVersion string) ([]runtime.Object, error) {
 	rawResources, err := syndesistemplate.GetUpgradeResources(syndesis, syndesistemplate.UpgradeParams{
 		InstallParams: syndesistemplate.InstallParams{
 			OAuthClientSecret: "-",
 		},
-		SyndesisVersion: a.operatorVersion,
+		SyndesisVersion: targetVersion,
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,50 @@
+package action
+
+import (
+	"github.com/operator-framework/operator-sdk/pkg/sdk"
+	"github.com/syndesisio/syndesis-operator/pkg/apis/syndesis/v1alpha1"
+	"github.com/syndesisio/syndesis-operator/pkg/syndesis/upgradecheck"
+	"github.com/syndesisio/syndesis-operator/pkg/syndesis/version"
+)
+
+// UpgradeCheck is a lightweight, non-mutating action that keeps
+// Status.AvailableUpgrade up to date so cluster admins can preview an upgrade
+// before flipping InstallationStatus to Upgrading. Unlike the other actions it
+// runs regardless of InstallationStatus, except while an upgrade is already in
+// flight.
+type UpgradeCheck struct {
+	operatorVersion string
+}
+
+func (a *UpgradeCheck) CanExecute(syndesis *v1alpha1.Syndesis) bool {
+	switch syndesis.Status.InstallationStatus {
+	case v1alpha1.SyndesisInstallationStatusUpgrading, v1alpha1.SyndesisInstallationStatusPostUpgrading:
+		return false
+	default:
+		return true
+	}
+}
+
+func (a *UpgradeCheck) Execute(syndesis *v1alpha1.Syndesis) error {
+	if a.operatorVersion == "" {
+		operatorVersion, err := version.GetSyndesisVersionFromOperatorTemplate()
+		if err != nil {
+			return err
+		}
+		a.operatorVersion = operatorVersion
+	}
+
+	computed, err := upgradecheck.Compute(syndesis, a.operatorVersion)
+	if err != nil {
+		return err
+	}
+
+	if upgradecheck.UpToDate(syndesis, computed) {
+		return nil
+	}
+
+	target := syndesis.DeepCopy()
+	target.Status.AvailableUpgrade = computed
+
+	return sdk.Update(target)
+}
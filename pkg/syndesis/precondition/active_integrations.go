@@ -0,0 +1,46 @@
+package precondition
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/operator-framework/operator-sdk/pkg/sdk"
+	"github.com/syndesisio/syndesis-operator/pkg/apis/syndesis/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// nonTerminalBuildPhases are the Integration build phases that indicate a build
+// is still in flight and shouldn't be interrupted by an upgrade.
+var nonTerminalBuildPhases = map[string]bool{
+	"Building":  true,
+	"Deploying": true,
+	"Starting":  true,
+}
+
+// noActiveIntegrations checks that no Integration in the namespace has a build
+// currently in a non-terminal phase, since the upgrade pod can leave those half
+// migrated.
+type noActiveIntegrations struct{}
+
+func (c *noActiveIntegrations) Name() string {
+	return "NoActiveIntegrationBuilds"
+}
+
+func (c *noActiveIntegrations) Run(ctx context.Context, syndesis *v1alpha1.Syndesis) error {
+	integrations := unstructured.UnstructuredList{}
+	integrations.SetAPIVersion("syndesis.io/v1alpha1")
+	integrations.SetKind("IntegrationList")
+
+	if err := sdk.List(syndesis.Namespace, &integrations); err != nil {
+		return err
+	}
+
+	for _, integration := range integrations.Items {
+		phase, _, _ := unstructured.NestedString(integration.Object, "status", "phase")
+		if nonTerminalBuildPhases[phase] {
+			return fmt.Errorf("integration %s has a build in progress (phase %s)", integration.GetName(), phase)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package precondition
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/operator-framework/operator-sdk/pkg/k8sclient"
+	"github.com/syndesisio/syndesis-operator/pkg/apis/syndesis/v1alpha1"
+)
+
+// requiredAPIGroupVersions are the Kubernetes/OpenShift API group versions the
+// rendered upgrade resources depend on.
+var requiredAPIGroupVersions = []string{
+	"apps/v1",
+	"route.openshift.io/v1",
+	"image.openshift.io/v1",
+}
+
+// apiVersionSupport checks that the target cluster's API server advertises the
+// group versions the upgrade resources need, so the upgrade pod doesn't fail
+// half way through because of an unsupported/removed API.
+type apiVersionSupport struct{}
+
+func (c *apiVersionSupport) Name() string {
+	return "APIVersionSupport"
+}
+
+func (c *apiVersionSupport) Run(ctx context.Context, syndesis *v1alpha1.Syndesis) error {
+	discovery := k8sclient.GetKubeClient().Discovery()
+
+	for _, gv := range requiredAPIGroupVersions {
+		if _, err := discovery.ServerResourcesForGroupVersion(gv); err != nil {
+			return fmt.Errorf("cluster does not support required API group version %s: %v", gv, err)
+		}
+	}
+
+	return nil
+}
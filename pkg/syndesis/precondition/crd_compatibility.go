@@ -0,0 +1,70 @@
+package precondition
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/syndesisio/syndesis-operator/pkg/apis/syndesis/v1alpha1"
+	"github.com/syndesisio/syndesis-operator/pkg/syndesis/version"
+)
+
+// crdCompatibility checks that the CRD schema used by the namespace's currently
+// installed Syndesis version is compatible with the one the operator's target
+// version expects, so the upgrade pod won't choke on a resource it can't read.
+// Schema-breaking changes to the Syndesis CRD are only ever shipped alongside a
+// major version bump, so the major version component is used as a stand-in for
+// the schema generation.
+type crdCompatibility struct{}
+
+func (c *crdCompatibility) Name() string {
+	return "CRDSchemaCompatibility"
+}
+
+func (c *crdCompatibility) Run(ctx context.Context, syndesis *v1alpha1.Syndesis) error {
+	namespaceVersion, err := version.GetSyndesisVersionFromNamespace(syndesis.Namespace)
+	if err != nil {
+		return err
+	}
+
+	if namespaceVersion == "" {
+		// Nothing installed yet, nothing to be incompatible with.
+		return nil
+	}
+
+	operatorVersion, err := version.GetSyndesisVersionFromOperatorTemplate()
+	if err != nil {
+		return err
+	}
+
+	nsGeneration, err := schemaGeneration(namespaceVersion)
+	if err != nil {
+		// Can't parse the installed version's generation: don't block on it.
+		return nil
+	}
+	opGeneration, err := schemaGeneration(operatorVersion)
+	if err != nil {
+		return nil
+	}
+
+	if nsGeneration != opGeneration {
+		return fmt.Errorf("CRD schema generation of installed version %s (generation %d) is no longer supported by operator version %s (generation %d)",
+			namespaceVersion, nsGeneration, operatorVersion, opGeneration)
+	}
+
+	return nil
+}
+
+// schemaGeneration extracts the major version component of v, used as the CRD
+// schema generation.
+func schemaGeneration(v string) (int, error) {
+	major := strings.SplitN(v, ".", 2)[0]
+
+	n, err := strconv.Atoi(major)
+	if err != nil {
+		return 0, fmt.Errorf("version %s is not in major.minor[.patch] form", v)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,34 @@
+package precondition
+
+import "testing"
+
+func TestSchemaGeneration(t *testing.T) {
+	tests := []struct {
+		version string
+		want    int
+		wantErr bool
+	}{
+		{"7.9", 7, false},
+		{"7.9.1", 7, false},
+		{"10.0", 10, false},
+		{"latest", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := schemaGeneration(tt.version)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("schemaGeneration(%q) expected an error, got none", tt.version)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("schemaGeneration(%q) returned unexpected error: %v", tt.version, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("schemaGeneration(%q) = %d, want %d", tt.version, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Package precondition gates the Upgrade action behind a set of checks that must
+// all pass before an upgrade pod is created, modeled on the preconditions used by
+// cluster-version-operator.
+package precondition
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/syndesisio/syndesis-operator/pkg/apis/syndesis/v1alpha1"
+)
+
+// Precondition is a single check that must succeed before Upgrade.Execute is
+// allowed to create an upgrade pod. Run should return a descriptive error when
+// the precondition is not met; it should not panic or mutate syndesis.
+type Precondition interface {
+	Name() string
+	Run(ctx context.Context, syndesis *v1alpha1.Syndesis) error
+}
+
+var registry []Precondition
+
+// Register adds a Precondition to the set run by RunAll. External operators can
+// call this from an init() function to plug in additional, e.g. cloud-specific,
+// checks (image availability, quota, ...).
+func Register(p Precondition) {
+	registry = append(registry, p)
+}
+
+// All returns the currently registered preconditions, in registration order.
+func All() []Precondition {
+	return registry
+}
+
+// RunAll runs every registered precondition against syndesis, returning a
+// PreconditionError summarising every failure, or nil if they all pass.
+func RunAll(ctx context.Context, syndesis *v1alpha1.Syndesis) error {
+	return runAgainst(registry, ctx, syndesis)
+}
+
+// runAgainst runs preconditions against syndesis. Split out from RunAll so
+// tests can exercise the aggregation logic against fake preconditions instead
+// of the real, sdk-backed registry.
+func runAgainst(preconditions []Precondition, ctx context.Context, syndesis *v1alpha1.Syndesis) error {
+	var failures []string
+
+	for _, p := range preconditions {
+		if err := p.Run(ctx, syndesis); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", p.Name(), err))
+		}
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	return &PreconditionError{Failures: failures}
+}
+
+// PreconditionError summarises every precondition that failed for a given run.
+type PreconditionError struct {
+	Failures []string
+}
+
+func (e *PreconditionError) Error() string {
+	msg := "preconditions not met:"
+	for _, f := range e.Failures {
+		msg += " [" + f + "]"
+	}
+	return msg
+}
+
+func init() {
+	Register(&crdCompatibility{})
+	Register(&provisionedStorageCapacity{})
+	Register(&noActiveIntegrations{})
+	Register(&apiVersionSupport{})
+}
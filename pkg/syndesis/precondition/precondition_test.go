@@ -0,0 +1,64 @@
+package precondition
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/syndesisio/syndesis-operator/pkg/apis/syndesis/v1alpha1"
+)
+
+type fakePrecondition struct {
+	name string
+	err  error
+}
+
+func (f *fakePrecondition) Name() string {
+	return f.name
+}
+
+func (f *fakePrecondition) Run(ctx context.Context, syndesis *v1alpha1.Syndesis) error {
+	return f.err
+}
+
+func TestRunAgainstAllPass(t *testing.T) {
+	preconditions := []Precondition{
+		&fakePrecondition{name: "A"},
+		&fakePrecondition{name: "B"},
+	}
+
+	if err := runAgainst(preconditions, context.Background(), &v1alpha1.Syndesis{}); err != nil {
+		t.Errorf("runAgainst() = %v, want nil", err)
+	}
+}
+
+func TestRunAgainstCollectsEveryFailure(t *testing.T) {
+	preconditions := []Precondition{
+		&fakePrecondition{name: "A", err: errors.New("boom-a")},
+		&fakePrecondition{name: "B"},
+		&fakePrecondition{name: "C", err: errors.New("boom-c")},
+	}
+
+	err := runAgainst(preconditions, context.Background(), &v1alpha1.Syndesis{})
+	if err == nil {
+		t.Fatal("runAgainst() = nil, want an error")
+	}
+
+	preconditionErr, ok := err.(*PreconditionError)
+	if !ok {
+		t.Fatalf("runAgainst() returned %T, want *PreconditionError", err)
+	}
+
+	if len(preconditionErr.Failures) != 2 {
+		t.Fatalf("got %d failures, want 2: %v", len(preconditionErr.Failures), preconditionErr.Failures)
+	}
+
+	msg := preconditionErr.Error()
+	if !strings.Contains(msg, "A: boom-a") || !strings.Contains(msg, "C: boom-c") {
+		t.Errorf("PreconditionError.Error() = %q, want it to mention both A and C failures", msg)
+	}
+	if strings.Contains(msg, "B:") {
+		t.Errorf("PreconditionError.Error() = %q, should not mention the passing precondition B", msg)
+	}
+}
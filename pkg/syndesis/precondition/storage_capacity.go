@@ -0,0 +1,48 @@
+package precondition
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/operator-framework/operator-sdk/pkg/sdk"
+	"github.com/syndesisio/syndesis-operator/pkg/apis/syndesis/v1alpha1"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// minProvisionedUpgradeCapacity is the minimum total provisioned capacity this
+// precondition requires across the namespace's bound PVs. This is a coarse
+// proxy for whether the upgrade pod's DB dump has room to run: the Kubernetes
+// API exposes no per-PVC used/available bytes, only the provisioned size, so
+// this cannot tell a nearly-full volume from an empty one.
+var minProvisionedUpgradeCapacity = resource.MustParse("1Gi")
+
+// provisionedStorageCapacity checks that the namespace's persistent volume
+// claims have at least minProvisionedUpgradeCapacity of total provisioned
+// capacity. It does not measure actual free space.
+type provisionedStorageCapacity struct{}
+
+func (c *provisionedStorageCapacity) Name() string {
+	return "MinimumProvisionedStorageCapacity"
+}
+
+func (c *provisionedStorageCapacity) Run(ctx context.Context, syndesis *v1alpha1.Syndesis) error {
+	claims := v1.PersistentVolumeClaimList{}
+	if err := sdk.List(syndesis.Namespace, &claims); err != nil {
+		return err
+	}
+
+	var total resource.Quantity
+	for _, pvc := range claims.Items {
+		if capacity, ok := pvc.Status.Capacity[v1.ResourceStorage]; ok {
+			total.Add(capacity)
+		}
+	}
+
+	if total.Cmp(minProvisionedUpgradeCapacity) < 0 {
+		return fmt.Errorf("namespace %s has %s of provisioned storage capacity, need at least %s for the upgrade DB dump",
+			syndesis.Namespace, total.String(), minProvisionedUpgradeCapacity.String())
+	}
+
+	return nil
+}
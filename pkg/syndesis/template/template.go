@@ -0,0 +1,84 @@
+// Package template renders the Kubernetes resources used to install and upgrade
+// Syndesis from the operator's bundled templates.
+package template
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	gotemplate "text/template"
+
+	"github.com/syndesisio/syndesis-operator/pkg/apis/syndesis/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	upgradeTemplatePath     = "/conf/syndesis-upgrade-template.yml"
+	postUpgradeTemplatePath = "/conf/syndesis-post-upgrade-template.yml"
+)
+
+// InstallParams carries the parameters common to every rendered template.
+type InstallParams struct {
+	OAuthClientSecret string
+}
+
+// UpgradeParams carries the parameters used to render the upgrade pod template.
+type UpgradeParams struct {
+	InstallParams
+
+	SyndesisVersion string
+}
+
+// GetUpgradeResources renders the upgrade template for the given Syndesis resource,
+// returning the raw Kubernetes objects (including the upgrade pod) to be applied.
+func GetUpgradeResources(syndesis *v1alpha1.Syndesis, params UpgradeParams) ([]runtime.RawExtension, error) {
+	return renderTemplate(upgradeTemplatePath, params)
+}
+
+// PostUpgradeParams carries the parameters used to render the post-upgrade Job
+// template.
+type PostUpgradeParams struct {
+	InstallParams
+
+	SyndesisVersion string
+}
+
+// GetPostUpgradeResources renders the post-upgrade template for the given
+// Syndesis resource, returning the raw Kubernetes objects (including the
+// migration Job) to be applied once the upgrade pod has succeeded.
+func GetPostUpgradeResources(syndesis *v1alpha1.Syndesis, params PostUpgradeParams) ([]runtime.RawExtension, error) {
+	return renderTemplate(postUpgradeTemplatePath, params)
+}
+
+// renderTemplate reads the Go template at path and executes it against params,
+// splitting the rendered output into the individual Kubernetes resources it
+// defines (one per "---"-separated YAML document), mirroring the document
+// splitting version.GetSyndesisVersionFromOperatorTemplate already relies on.
+func renderTemplate(path string, params interface{}) ([]runtime.RawExtension, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading template %s: %v", path, err)
+	}
+
+	tmpl, err := gotemplate.New(path).Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("parsing template %s: %v", path, err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, params); err != nil {
+		return nil, fmt.Errorf("rendering template %s: %v", path, err)
+	}
+
+	var resources []runtime.RawExtension
+	for _, doc := range strings.Split(rendered.String(), "\n---\n") {
+		doc = strings.TrimSpace(doc)
+		if doc == "" {
+			continue
+		}
+		resources = append(resources, runtime.RawExtension{Raw: []byte(doc)})
+	}
+
+	return resources, nil
+}
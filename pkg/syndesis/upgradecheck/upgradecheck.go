@@ -0,0 +1,44 @@
+// Package upgradecheck computes the upgrade a Syndesis resource would go
+// through without mutating InstallationStatus, shared by the non-mutating
+// controller loop and the syndesis-upgrade-check CLI.
+package upgradecheck
+
+import (
+	"github.com/syndesisio/syndesis-operator/pkg/apis/syndesis/v1alpha1"
+	"github.com/syndesisio/syndesis-operator/pkg/syndesis/version"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Compute returns the upgrade this operator would perform for syndesis, given
+// operatorVersion as the version the operator is currently running.
+func Compute(syndesis *v1alpha1.Syndesis, operatorVersion string) (*v1alpha1.AvailableUpgrade, error) {
+	namespaceVersion, err := version.GetSyndesisVersionFromNamespace(syndesis.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := version.ComputeUpgradePath(namespaceVersion, operatorVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1alpha1.AvailableUpgrade{
+		CurrentVersion: namespaceVersion,
+		TargetVersion:  operatorVersion,
+		Path:           path,
+		CheckedAt:      metav1.Now(),
+	}, nil
+}
+
+// UpToDate reports whether syndesis's AvailableUpgrade already reflects the
+// given operator version and current namespace version, so callers can skip a
+// redundant status update.
+func UpToDate(syndesis *v1alpha1.Syndesis, computed *v1alpha1.AvailableUpgrade) bool {
+	existing := syndesis.Status.AvailableUpgrade
+	if existing == nil {
+		return false
+	}
+
+	return existing.CurrentVersion == computed.CurrentVersion &&
+		existing.TargetVersion == computed.TargetVersion
+}
@@ -0,0 +1,168 @@
+// Package version resolves the Syndesis version installed in a namespace and the
+// version shipped by the operator's own templates.
+package version
+
+import (
+	stderrors "errors"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+
+	"github.com/operator-framework/operator-sdk/pkg/sdk"
+	"k8s.io/api/core/v1"
+)
+
+const (
+	// SyndesisVersionConfigMapName is the config map used to stamp the installed version.
+	SyndesisVersionConfigMapName = "syndesis-version"
+	// SyndesisVersionConfigMapKey is the key, within SyndesisVersionConfigMapName,
+	// that holds the installed version.
+	SyndesisVersionConfigMapKey = "version"
+
+	operatorTemplatePath = "/conf/syndesis-template.yml"
+)
+
+// UpgradePathResolver computes the ordered chain of intermediate versions an
+// installation must step through to get from "from" to "to". The returned slice
+// does not include "from" but does include "to" as its last element. Downstream
+// distributions can register their own resolver via SetUpgradePathResolver to
+// control which intermediate templates get pulled in, e.g. to skip versions that
+// don't ship an upgrade template of their own.
+type UpgradePathResolver func(from, to string) ([]string, error)
+
+var upgradePathResolver UpgradePathResolver = defaultUpgradePathResolver
+
+// SetUpgradePathResolver overrides the resolver used by ComputeUpgradePath.
+func SetUpgradePathResolver(resolver UpgradePathResolver) {
+	upgradePathResolver = resolver
+}
+
+// ComputeUpgradePath returns the ordered chain of intermediate versions between
+// "from" and "to", using the currently registered UpgradePathResolver.
+func ComputeUpgradePath(from, to string) ([]string, error) {
+	return upgradePathResolver(from, to)
+}
+
+// defaultUpgradePathResolver steps one minor version at a time between "from"
+// and "to", e.g. 7.9 -> 7.10 -> 7.11.1 rather than jumping straight to the
+// target. Versions that don't parse as dotted numeric releases are treated as
+// a single direct hop.
+func defaultUpgradePathResolver(from, to string) ([]string, error) {
+	fromParts, err := parseVersion(from)
+	if err != nil {
+		return []string{to}, nil
+	}
+	toParts, err := parseVersion(to)
+	if err != nil {
+		return []string{to}, nil
+	}
+
+	if fromParts[0] != toParts[0] || fromParts[1] >= toParts[1] {
+		return []string{to}, nil
+	}
+
+	path := make([]string, 0, toParts[1]-fromParts[1])
+	for minor := fromParts[1] + 1; minor < toParts[1]; minor++ {
+		path = append(path, strings.Join([]string{intToStr(fromParts[0]), intToStr(minor)}, "."))
+	}
+	path = append(path, to)
+
+	return path, nil
+}
+
+// parseVersion splits a "major.minor[.patch]" version into its numeric major
+// and minor components.
+func parseVersion(v string) ([2]int, error) {
+	parts := strings.SplitN(v, ".", 3)
+	if len(parts) < 2 {
+		return [2]int{}, errInvalidVersion
+	}
+
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return [2]int{}, errInvalidVersion
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return [2]int{}, errInvalidVersion
+	}
+
+	return [2]int{major, minor}, nil
+}
+
+func intToStr(i int) string {
+	return strconv.Itoa(i)
+}
+
+var errInvalidVersion = stderrors.New("version is not in major.minor[.patch] form")
+
+// NamespaceVersionGetter resolves the Syndesis version currently installed in a
+// namespace. The default, sdkNamespaceVersionGetter, goes through the
+// operator-sdk's package-global client, which is only ever bootstrapped by the
+// operator's own main. Standalone binaries that build their own client from an
+// explicit kubeconfig (e.g. syndesis-upgrade-check) must call
+// SetNamespaceVersionGetter so GetSyndesisVersionFromNamespace resolves against
+// that same client instead of silently falling back to the global one.
+type NamespaceVersionGetter func(namespace string) (string, error)
+
+var namespaceVersionGetter NamespaceVersionGetter = sdkNamespaceVersionGetter
+
+// SetNamespaceVersionGetter overrides the getter used by
+// GetSyndesisVersionFromNamespace.
+func SetNamespaceVersionGetter(getter NamespaceVersionGetter) {
+	namespaceVersionGetter = getter
+}
+
+// GetSyndesisVersionFromNamespace returns the Syndesis version currently installed
+// in the given namespace, as recorded in the syndesis-version config map.
+func GetSyndesisVersionFromNamespace(namespace string) (string, error) {
+	return namespaceVersionGetter(namespace)
+}
+
+// sdkNamespaceVersionGetter is the default NamespaceVersionGetter, resolving the
+// config map through the operator-sdk's package-global client.
+func sdkNamespaceVersionGetter(namespace string) (string, error) {
+	cm := v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SyndesisVersionConfigMapName,
+			Namespace: namespace,
+		},
+	}
+
+	err := sdk.Get(&cm)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return cm.Data[SyndesisVersionConfigMapKey], nil
+}
+
+// GetSyndesisVersionFromOperatorTemplate returns the Syndesis version bundled with
+// the operator binary, read from its install template.
+func GetSyndesisVersionFromOperatorTemplate() (string, error) {
+	content, err := ioutil.ReadFile(operatorTemplatePath)
+	if err != nil {
+		return "", err
+	}
+
+	decoder := serializer.NewCodecFactory(runtime.NewScheme()).UniversalDeserializer()
+	for _, doc := range strings.Split(string(content), "\n---\n") {
+		cm := v1.ConfigMap{}
+		if _, _, err := decoder.Decode([]byte(doc), nil, &cm); err != nil {
+			continue
+		}
+		if cm.Name == SyndesisVersionConfigMapName {
+			return cm.Data[SyndesisVersionConfigMapKey], nil
+		}
+	}
+
+	return "", nil
+}
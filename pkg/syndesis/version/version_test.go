@@ -0,0 +1,85 @@
+package version
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDefaultUpgradePathResolver(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		want     []string
+	}{
+		{"same minor", "7.9", "7.9", []string{"7.9"}},
+		{"adjacent minor", "7.9", "7.10", []string{"7.10"}},
+		{"skips intermediate minors", "7.9", "7.12", []string{"7.10", "7.11", "7.12"}},
+		{"patch on target is kept as-is", "7.9", "7.11.1", []string{"7.10", "7.11.1"}},
+		{"major mismatch hops directly", "6.9", "7.1", []string{"7.1"}},
+		{"unparsable from hops directly", "latest", "7.1", []string{"7.1"}},
+		{"unparsable to hops directly", "7.9", "latest", []string{"latest"}},
+		{"empty from hops directly", "", "7.9", []string{"7.9"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := defaultUpgradePathResolver(tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("defaultUpgradePathResolver(%q, %q) returned error: %v", tt.from, tt.to, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("defaultUpgradePathResolver(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeUpgradePathUsesRegisteredResolver(t *testing.T) {
+	defer SetUpgradePathResolver(defaultUpgradePathResolver)
+
+	SetUpgradePathResolver(func(from, to string) ([]string, error) {
+		return []string{"custom-hop", to}, nil
+	})
+
+	got, err := ComputeUpgradePath("7.9", "7.11")
+	if err != nil {
+		t.Fatalf("ComputeUpgradePath returned error: %v", err)
+	}
+
+	want := []string{"custom-hop", "7.11"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ComputeUpgradePath = %v, want %v", got, want)
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    [2]int
+		wantErr bool
+	}{
+		{"7.9", [2]int{7, 9}, false},
+		{"7.9.1", [2]int{7, 9}, false},
+		{"7", [2]int{}, true},
+		{"latest", [2]int{}, true},
+		{"x.9", [2]int{}, true},
+		{"7.x", [2]int{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseVersion(tt.version)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseVersion(%q) expected an error, got none", tt.version)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseVersion(%q) returned unexpected error: %v", tt.version, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseVersion(%q) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
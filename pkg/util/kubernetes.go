@@ -0,0 +1,17 @@
+// Package util provides small Kubernetes helpers shared across the operator's
+// reconciliation actions.
+package util
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+var decoder = scheme.Codecs.UniversalDeserializer()
+
+// LoadKubernetesResource decodes a single Kubernetes resource from its raw JSON/YAML
+// representation, as found in a rendered template.
+func LoadKubernetesResource(raw []byte) (runtime.Object, error) {
+	obj, _, err := decoder.Decode(raw, nil, nil)
+	return obj, err
+}